@@ -0,0 +1,200 @@
+package reflections
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// AssignOption configures Assign's field-matching and type-conversion
+// behavior.
+type AssignOption func(*assignConfig)
+
+type assignConfig struct {
+	tagKey    string
+	converter func(from reflect.Value, toType reflect.Type) (reflect.Value, bool, error)
+}
+
+// WithAssignTag matches src and dst fields by the given struct tag (e.g.
+// "mapstructure" or "json") instead of by Go field name.
+func WithAssignTag(tagKey string) AssignOption {
+	return func(c *assignConfig) { c.tagKey = tagKey }
+}
+
+// WithConverter registers a fallback invoked when a src field's type isn't
+// directly assignable or convertible to the matching dst field's type —
+// e.g. string <-> time.Time via a caller-supplied layout. Returning ok=false
+// declines the conversion, which Assign then reports as a field error.
+func WithConverter(fn func(from reflect.Value, toType reflect.Type) (reflect.Value, bool, error)) AssignOption {
+	return func(c *assignConfig) { c.converter = fn }
+}
+
+// Assign copies values from src into dst by matching field names (or the
+// tag configured via WithAssignTag), converting between assignable and
+// convertible kinds as needed. dst must be a pointer to a struct, and src
+// a struct or pointer to one. Nested structs recurse, and slices/maps are
+// copied element-wise. Every per-field failure is collected into the
+// returned error via errors.Join, rather than stopping at the first one.
+func Assign(dst, src interface{}, opts ...AssignOption) error {
+	if !isPointer(dst) {
+		return errors.New("Cannot use Assign on a non-pointer dst")
+	}
+
+	cfg := &assignConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Ptr {
+		srcValue = srcValue.Elem()
+	}
+	if dstValue.Kind() != reflect.Struct || srcValue.Kind() != reflect.Struct {
+		return errors.New("Assign requires dst and src to be structs or pointers to structs")
+	}
+
+	return errors.Join(assignStruct(dstValue, srcValue, cfg)...)
+}
+
+func assignStruct(dst, src reflect.Value, cfg *assignConfig) []error {
+	var errs []error
+	srcType := src.Type()
+	dstType := dst.Type()
+
+	for i := 0; i < dstType.NumField(); i++ {
+		dstField := dstType.Field(i)
+		if !isExportableField(dstField) {
+			continue
+		}
+
+		srcIdx := findMatchingField(srcType, dstField, cfg.tagKey)
+		if srcIdx < 0 || !isExportableField(srcType.Field(srcIdx)) {
+			continue
+		}
+
+		if err := assignField(dst.Field(i), src.Field(srcIdx), cfg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dstField.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// findMatchingField locates the src field that corresponds to dstField,
+// matching by tagKey when set and falling back to the Go field name.
+func findMatchingField(srcType reflect.Type, dstField reflect.StructField, tagKey string) int {
+	wantName := fieldMatchName(dstField, tagKey)
+	for i := 0; i < srcType.NumField(); i++ {
+		if fieldMatchName(srcType.Field(i), tagKey) == wantName {
+			return i
+		}
+	}
+	return -1
+}
+
+func fieldMatchName(field reflect.StructField, tagKey string) string {
+	if tagKey != "" {
+		if tag, ok := field.Tag.Lookup(tagKey); ok && tag != "" && tag != "-" {
+			return tag
+		}
+	}
+	return field.Name
+}
+
+func assignField(dst, src reflect.Value, cfg *assignConfig) error {
+	switch {
+	case isSequence(src.Kind()) && isSequence(dst.Kind()):
+		return assignSequence(dst, src, cfg)
+
+	case src.Kind() == reflect.Map && dst.Kind() == reflect.Map:
+		return assignMap(dst, src, cfg)
+
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return errors.Join(assignStruct(dst, src, cfg)...)
+
+	case src.Type().ConvertibleTo(dst.Type()) && isSimpleConversion(src.Kind(), dst.Kind()):
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+
+	case cfg.converter != nil:
+		converted, ok, err := cfg.converter(src, dst.Type())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("no conversion from %s to %s", src.Type(), dst.Type())
+		}
+		dst.Set(converted)
+		return nil
+
+	default:
+		return fmt.Errorf("no conversion from %s to %s", src.Type(), dst.Type())
+	}
+}
+
+func isSequence(k reflect.Kind) bool {
+	return k == reflect.Slice || k == reflect.Array
+}
+
+func isSimpleConversion(from, to reflect.Kind) bool {
+	return (isNumericKind(from) && isNumericKind(to)) || (from == reflect.String && to == reflect.String)
+}
+
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func assignSequence(dst, src reflect.Value, cfg *assignConfig) error {
+	if src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice && src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Slice {
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+	} else if src.Len() != dst.Len() {
+		return fmt.Errorf("array length mismatch: %d != %d", src.Len(), dst.Len())
+	}
+
+	var errs []error
+	for i := 0; i < src.Len(); i++ {
+		if err := assignField(dst.Index(i), src.Index(i), cfg); err != nil {
+			errs = append(errs, fmt.Errorf("[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func assignMap(dst, src reflect.Value, cfg *assignConfig) error {
+	if !src.Type().Key().AssignableTo(dst.Type().Key()) {
+		return fmt.Errorf("map key type mismatch: %s != %s", src.Type().Key(), dst.Type().Key())
+	}
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+	var errs []error
+	iter := src.MapRange()
+	for iter.Next() {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		if err := assignField(elem, iter.Value(), cfg); err != nil {
+			errs = append(errs, fmt.Errorf("[%v]: %w", iter.Key().Interface(), err))
+			continue
+		}
+		dst.SetMapIndex(iter.Key(), elem)
+	}
+	return errors.Join(errs...)
+}
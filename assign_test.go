@@ -0,0 +1,136 @@
+package reflections
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type assignInner struct {
+	Code int
+}
+
+type assignSrc struct {
+	Name  string
+	Inner assignInner
+	Tags  []string
+	Score int32
+	Meta  map[string]int
+}
+
+type assignDst struct {
+	Name  string
+	Inner assignInner
+	Tags  []string
+	Score int64
+	Meta  map[string]int
+}
+
+type assignConverterSrc struct {
+	When string
+}
+
+type assignConverterDst struct {
+	When time.Time
+}
+
+func TestAssignCopiesMatchingFieldsAndRecurses(t *testing.T) {
+	src := assignSrc{
+		Name:  "a",
+		Inner: assignInner{Code: 7},
+		Tags:  []string{"x", "y"},
+		Score: 5,
+		Meta:  map[string]int{"k": 1},
+	}
+	var dst assignDst
+
+	if err := Assign(&dst, &src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "a" {
+		t.Fatalf("Name = %q", dst.Name)
+	}
+	if dst.Inner.Code != 7 {
+		t.Fatalf("Inner.Code = %d", dst.Inner.Code)
+	}
+	if len(dst.Tags) != 2 || dst.Tags[0] != "x" || dst.Tags[1] != "y" {
+		t.Fatalf("Tags = %v", dst.Tags)
+	}
+	if dst.Score != 5 {
+		t.Fatalf("Score = %d", dst.Score)
+	}
+	if dst.Meta["k"] != 1 {
+		t.Fatalf("Meta = %v", dst.Meta)
+	}
+}
+
+type assignTagSrc struct {
+	FullName string `mapstructure:"name"`
+}
+
+type assignTagDst struct {
+	Name string `mapstructure:"name"`
+}
+
+func TestAssignWithAssignTagMatchesByTag(t *testing.T) {
+	src := assignTagSrc{FullName: "hi"}
+	var dst assignTagDst
+
+	if err := Assign(&dst, &src, WithAssignTag("mapstructure")); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "hi" {
+		t.Fatalf("Name = %q, want %q", dst.Name, "hi")
+	}
+}
+
+func TestAssignWithConverterHandlesUnconvertibleTypes(t *testing.T) {
+	src := assignConverterSrc{When: "2020-01-02"}
+	var dst assignConverterDst
+
+	layout := "2006-01-02"
+	err := Assign(&dst, &src, WithConverter(func(from reflect.Value, toType reflect.Type) (reflect.Value, bool, error) {
+		if from.Kind() != reflect.String || toType != reflect.TypeOf(time.Time{}) {
+			return reflect.Value{}, false, nil
+		}
+		parsed, parseErr := time.Parse(layout, from.String())
+		if parseErr != nil {
+			return reflect.Value{}, false, parseErr
+		}
+		return reflect.ValueOf(parsed), true, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !dst.When.Equal(want) {
+		t.Fatalf("When = %v, want %v", dst.When, want)
+	}
+}
+
+func TestAssignCollectsMultipleFieldErrors(t *testing.T) {
+	type badSrc struct {
+		A string
+		B string
+	}
+	type badDst struct {
+		A int
+		B int
+	}
+
+	err := Assign(&badDst{}, &badSrc{A: "x", B: "y"})
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "A:") || !strings.Contains(msg, "B:") {
+		t.Fatalf("expected errors for both A and B, got %q", msg)
+	}
+}
+
+func TestAssignRequiresPointerDst(t *testing.T) {
+	if err := Assign(assignDst{}, &assignSrc{}); err == nil {
+		t.Fatal("expected error for non-pointer dst, got none")
+	}
+}
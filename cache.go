@@ -0,0 +1,199 @@
+package reflections
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldMeta is the precomputed description of a single struct field,
+// recorded once per reflect.Type instead of being recomputed on every call.
+type fieldMeta struct {
+	Index      []int
+	Name       string
+	DottedName string
+	Tag        reflect.StructTag
+	Exported   bool
+	Kind       reflect.Kind
+}
+
+// typeMeta bundles the three traversal shapes the package's field walkers
+// need for a given struct type:
+//   - direct holds the type's own immediate fields, in declaration order,
+//     for Items and Tags.
+//   - promoted maps a field name to its index path following Go's
+//     embedding promotion rules, for the path segment resolver.
+//   - recursive flattens every nested struct/pointer-to-struct field
+//     (named or embedded) into the parent, for Fields and FieldsNames.
+type typeMeta struct {
+	direct    []fieldMeta
+	promoted  map[string][]int
+	recursive []fieldMeta
+}
+
+var typeCache sync.Map // reflect.Type -> *typeMeta
+
+// PrecomputeType warms the type cache for t (or the struct type t points
+// to) so that the first real GetField/SetField/Fields/Items/... call
+// against a value of this type doesn't pay the cost of building field
+// metadata on the hot path. Safe to call concurrently.
+func PrecomputeType(t reflect.Type) {
+	cachedTypeMeta(t)
+}
+
+// cachedTypeMeta returns the memoized typeMeta for t, building and storing
+// it on first use. t may be a struct type or a pointer to one.
+func cachedTypeMeta(t reflect.Type) *typeMeta {
+	t = derefType(t)
+
+	if v, ok := typeCache.Load(t); ok {
+		return v.(*typeMeta)
+	}
+
+	meta := buildTypeMeta(t)
+	actual, _ := typeCache.LoadOrStore(t, meta)
+	return actual.(*typeMeta)
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func buildTypeMeta(t reflect.Type) *typeMeta {
+	meta := &typeMeta{promoted: map[string][]int{}}
+	if t.Kind() != reflect.Struct {
+		return meta
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		meta.direct = append(meta.direct, fieldMeta{
+			Index:    []int{i},
+			Name:     sf.Name,
+			Tag:      sf.Tag,
+			Exported: isExportableField(sf),
+			Kind:     sf.Type.Kind(),
+		})
+	}
+
+	meta.promoted = buildPromoted(t)
+	meta.recursive = buildRecursive(t, nil, "", map[reflect.Type]bool{t: true})
+	return meta
+}
+
+// buildPromoted computes a name -> index-path lookup equivalent to what
+// reflect.Type.FieldByName resolves at runtime: a breadth-first search
+// through anonymous (embedded) fields where the shallowest match for a
+// name wins. A name that occurs more than once at its shallowest depth is
+// ambiguous under Go's own selector rules and is left out of the map
+// entirely, rather than arbitrarily picking one of the candidates — once a
+// depth has been resolved (found, or found ambiguous) for a name, deeper
+// occurrences of that name are ignored, matching "shallowest depth wins,
+// ties at that depth are invisible". Types already seen at a shallower
+// depth are not re-queued, which bounds the search in the presence of
+// self-referential embedding (e.g. `type Node struct { *Node }`); a type
+// embedded more than once at the same depth is queued once per occurrence,
+// so its fields come out ambiguous there just as they do for FieldByName.
+func buildPromoted(root reflect.Type) map[string][]int {
+	byName := map[string][]int{}
+	resolved := map[string]bool{}
+
+	type queued struct {
+		t     reflect.Type
+		index []int
+	}
+	type candidate struct {
+		path []int
+	}
+
+	seen := map[reflect.Type]bool{root: true}
+	level := []queued{{t: root}}
+
+	for len(level) > 0 {
+		var next []queued
+		levelNames := map[string][]candidate{}
+
+		for _, item := range level {
+			for i := 0; i < item.t.NumField(); i++ {
+				sf := item.t.Field(i)
+				path := appendIndex(item.index, i)
+				levelNames[sf.Name] = append(levelNames[sf.Name], candidate{path: path})
+
+				if !sf.Anonymous {
+					continue
+				}
+				embedded := derefType(sf.Type)
+				if embedded.Kind() == reflect.Struct && !seen[embedded] {
+					next = append(next, queued{t: embedded, index: path})
+				}
+			}
+		}
+		for _, q := range next {
+			seen[q.t] = true
+		}
+
+		for name, candidates := range levelNames {
+			if resolved[name] {
+				continue
+			}
+			resolved[name] = true
+			if len(candidates) == 1 {
+				byName[name] = candidates[0].path
+			}
+			// len > 1: ambiguous at its shallowest depth, stays unresolved.
+		}
+
+		level = next
+	}
+
+	return byName
+}
+
+// buildRecursive flattens every exported field of t into a single list,
+// recursing into nested struct and pointer-to-struct fields (named or
+// embedded) the way Fields and FieldsNames have always done. ancestors
+// guards against infinite recursion through self-referential types.
+func buildRecursive(t reflect.Type, indexPrefix []int, namePrefix string, ancestors map[reflect.Type]bool) []fieldMeta {
+	var fields []fieldMeta
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !isExportableField(sf) {
+			continue
+		}
+
+		path := appendIndex(indexPrefix, i)
+		dotted := sf.Name
+		if namePrefix != "" {
+			dotted = namePrefix + "." + sf.Name
+		}
+
+		fields = append(fields, fieldMeta{
+			Index:      path,
+			Name:       sf.Name,
+			DottedName: dotted,
+			Tag:        sf.Tag,
+			Exported:   true,
+			Kind:       sf.Type.Kind(),
+		})
+
+		nested := derefType(sf.Type)
+		if nested.Kind() != reflect.Struct || ancestors[nested] {
+			continue
+		}
+		ancestors[nested] = true
+		fields = append(fields, buildRecursive(nested, path, dotted, ancestors)...)
+		delete(ancestors, nested)
+	}
+
+	return fields
+}
+
+func appendIndex(prefix []int, i int) []int {
+	path := make([]int, len(prefix)+1)
+	copy(path, prefix)
+	path[len(path)-1] = i
+	return path
+}
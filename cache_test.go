@@ -0,0 +1,95 @@
+package reflections
+
+import (
+	"reflect"
+	"testing"
+)
+
+type CacheLeaf struct {
+	Value string
+}
+
+type CacheMiddle struct {
+	CacheLeaf
+	Extra int
+}
+
+type cacheRoot struct {
+	CacheMiddle
+	Name string
+}
+
+func TestCachedTypeMetaPromotesEmbeddedFields(t *testing.T) {
+	meta := cachedTypeMeta(reflect.TypeOf(cacheRoot{}))
+
+	path, ok := meta.promoted["Value"]
+	if !ok {
+		t.Fatal("expected Value promoted from embedded CacheLeaf")
+	}
+	want := []int{0, 0, 0}
+	if !reflect.DeepEqual(path, want) {
+		t.Fatalf("path = %v, want %v", path, want)
+	}
+
+	if _, ok := meta.promoted["Name"]; !ok {
+		t.Fatal("expected direct field Name in promoted map")
+	}
+}
+
+// Regression test: a field name that is ambiguous at its shallowest
+// embedding depth must be left out of the promoted map entirely, matching
+// Go's own selector resolution, instead of "first one seen" silently
+// picking one of the candidates.
+type ambiguousA struct {
+	Foo string
+}
+
+type ambiguousB struct {
+	Foo string
+}
+
+type ambiguousCombo struct {
+	ambiguousA
+	ambiguousB
+}
+
+func TestCachedTypeMetaLeavesAmbiguousFieldUnresolved(t *testing.T) {
+	meta := cachedTypeMeta(reflect.TypeOf(ambiguousCombo{}))
+
+	if _, ok := meta.promoted["Foo"]; ok {
+		t.Fatal("ambiguous field Foo should not be resolved")
+	}
+
+	c := ambiguousCombo{ambiguousA{Foo: "a"}, ambiguousB{Foo: "b"}}
+	if _, err := GetField(&c, "Foo"); err == nil {
+		t.Fatal("expected GetField to error on ambiguous field, got none")
+	}
+}
+
+func TestCachedTypeMetaDirectAndRecursive(t *testing.T) {
+	meta := cachedTypeMeta(reflect.TypeOf(cacheRoot{}))
+
+	if len(meta.direct) != 2 {
+		t.Fatalf("direct = %v, want 2 fields", meta.direct)
+	}
+
+	var sawValue bool
+	for _, f := range meta.recursive {
+		if f.DottedName == "CacheMiddle.CacheLeaf.Value" {
+			sawValue = true
+		}
+	}
+	if !sawValue {
+		t.Fatalf("recursive fields missing nested dotted name: %+v", meta.recursive)
+	}
+}
+
+func TestPrecomputeTypeIsIdempotent(t *testing.T) {
+	t1 := reflect.TypeOf(cacheRoot{})
+	PrecomputeType(t1)
+	first := cachedTypeMeta(t1)
+	second := cachedTypeMeta(t1)
+	if first != second {
+		t.Fatal("expected cachedTypeMeta to return the same cached pointer")
+	}
+}
@@ -0,0 +1,161 @@
+package reflections
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// ptrKey identifies an already-visited pointer during Clone's cycle
+// detection. The type is part of the key because two unrelated pointer
+// fields may, in principle, alias the same address through unsafe code.
+type ptrKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// Clone returns a deep copy of src. src can be a struct, pointer, slice,
+// map, or array, in any combination. Unexported fields are copied through
+// an unsafe.NewAt alias so third-party types clone correctly, and cyclic
+// pointers resolve to the same cloned value instead of recursing forever.
+// Fields tagged `reflections:"-"` are left at their zero value.
+func Clone(src interface{}) (interface{}, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	visited := make(map[ptrKey]reflect.Value)
+	cloned, err := cloneValue(reflect.ValueOf(src), visited)
+	if err != nil {
+		return nil, err
+	}
+	return cloned.Interface(), nil
+}
+
+func cloneValue(src reflect.Value, visited map[ptrKey]reflect.Value) (reflect.Value, error) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return src, nil
+		}
+		key := ptrKey{ptr: src.Pointer(), typ: src.Type()}
+		if dst, ok := visited[key]; ok {
+			return dst, nil
+		}
+		dst := reflect.New(src.Type().Elem())
+		visited[key] = dst
+		elem, err := cloneValue(src.Elem(), visited)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		dst.Elem().Set(elem)
+		return dst, nil
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return src, nil
+		}
+		elem, err := cloneValue(src.Elem(), visited)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(elem)
+		return dst, nil
+
+	case reflect.Struct:
+		return cloneStruct(src, visited)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return src, nil
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			elem, err := cloneValue(src.Index(i), visited)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			dst.Index(i).Set(elem)
+		}
+		return dst, nil
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			elem, err := cloneValue(src.Index(i), visited)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			dst.Index(i).Set(elem)
+		}
+		return dst, nil
+
+	case reflect.Map:
+		if src.IsNil() {
+			return src, nil
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			k, err := cloneValue(iter.Key(), visited)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v, err := cloneValue(iter.Value(), visited)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			dst.SetMapIndex(k, v)
+		}
+		return dst, nil
+
+	default:
+		return src, nil
+	}
+}
+
+// cloneStruct clones a struct field by field, aliasing unexported fields
+// through unsafe.NewAt so they can be read and written without tripping
+// reflect's "obtained from unexported field" panics.
+func cloneStruct(src reflect.Value, visited map[ptrKey]reflect.Value) (reflect.Value, error) {
+	if !src.CanAddr() {
+		addr := reflect.New(src.Type())
+		addr.Elem().Set(src)
+		src = addr.Elem()
+	}
+
+	structType := src.Type()
+	dst := reflect.New(structType).Elem()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if isOptedOut(field) {
+			continue
+		}
+
+		srcField := src.Field(i)
+		dstField := dst.Field(i)
+		if !isExportableField(field) {
+			srcField = reflect.NewAt(field.Type, unsafe.Pointer(srcField.UnsafeAddr())).Elem()
+			dstField = reflect.NewAt(field.Type, unsafe.Pointer(dstField.UnsafeAddr())).Elem()
+		}
+
+		cloned, err := cloneValue(srcField, visited)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		dstField.Set(cloned)
+	}
+
+	return dst, nil
+}
+
+// optOutTagValue is the `reflections` tag value that excludes a field from
+// both Clone and Merge.
+const optOutTagValue = "-"
+
+// isOptedOut reports whether field carries the `reflections:"-"` opt-out
+// tag, used by Clone and Merge to skip fields the caller wants untouched.
+func isOptedOut(field reflect.StructField) bool {
+	return field.Tag.Get(defaultExportTagKey) == optOutTagValue
+}
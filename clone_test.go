@@ -0,0 +1,64 @@
+package reflections
+
+import "testing"
+
+type cloneNode struct {
+	Name string
+	next *cloneNode
+	tags map[string]int
+}
+
+func TestCloneDeepCopiesAndPreservesCycles(t *testing.T) {
+	a := &cloneNode{Name: "a", tags: map[string]int{"x": 1}}
+	b := &cloneNode{Name: "b", next: a}
+	a.next = b // cycle
+
+	out, err := Clone(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clonedA, ok := out.(*cloneNode)
+	if !ok {
+		t.Fatalf("unexpected clone type %T", out)
+	}
+	if clonedA == a {
+		t.Fatal("expected a new pointer")
+	}
+	if clonedA.Name != "a" || clonedA.next.Name != "b" {
+		t.Fatalf("bad clone: %+v", clonedA)
+	}
+	if clonedA.next.next != clonedA {
+		t.Fatalf("cycle not preserved: %p vs %p", clonedA.next.next, clonedA)
+	}
+
+	clonedA.tags["x"] = 2
+	if a.tags["x"] != 1 {
+		t.Fatal("map not deep-copied")
+	}
+
+	clonedA.next.Name = "mutated"
+	if b.Name != "b" {
+		t.Fatal("struct with unexported fields not deep-copied")
+	}
+}
+
+type cloneOptOut struct {
+	Keep    string
+	Dropped string `reflections:"-"`
+}
+
+func TestCloneHonorsOptOutTag(t *testing.T) {
+	src := cloneOptOut{Keep: "keep", Dropped: "drop"}
+
+	out, err := Clone(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloned := out.(cloneOptOut)
+	if cloned.Keep != "keep" {
+		t.Fatalf("Keep not copied: %+v", cloned)
+	}
+	if cloned.Dropped != "" {
+		t.Fatalf("opted-out field should stay zero, got %q", cloned.Dropped)
+	}
+}
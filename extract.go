@@ -0,0 +1,102 @@
+package reflections
+
+import (
+	"errors"
+	"reflect"
+)
+
+// defaultExportTagKey is the struct tag key Extract looks at when no tag
+// key is explicitly provided.
+const defaultExportTagKey = "reflections"
+
+// exportTagValue is the tag value that opts a field into Extract's output.
+const exportTagValue = "export"
+
+// Extract walks obj and returns a flat map of every field tagged
+// `reflections:"export"`. obj can whether be a structure or pointer to
+// structure. It is the declarative counterpart to Items, which emits every
+// exported field regardless of tagging.
+func Extract(obj interface{}) (map[string]interface{}, error) {
+	return ExtractWithTag(obj, defaultExportTagKey)
+}
+
+// ExtractWithTag behaves like Extract but reads the export marker from
+// tagKey instead of the "reflections" tag.
+func ExtractWithTag(obj interface{}, tagKey string) (map[string]interface{}, error) {
+	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return nil, errors.New("Cannot use Extract on a non-struct interface")
+	}
+
+	out := make(map[string]interface{})
+	extractInto(out, reflectValue(obj), tagKey)
+	return out, nil
+}
+
+// extractInto fills out with the exported fields of structValue. Embedded
+// (anonymous) fields are flattened into out unless their declaration itself
+// carries the export tag, in which case they are emitted as a nested map
+// under their type name. Embedded interfaces are skipped. Regular fields
+// are handled first so that, on a name collision with a flattened embedded
+// field, the parent's own field always keeps the plain name and the
+// embedded one is demoted to "EmbeddedTypeName.FieldName".
+func extractInto(out map[string]interface{}, structValue reflect.Value, tagKey string) {
+	structType := structValue.Type()
+
+	var anonymous []reflect.StructField
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous {
+			anonymous = append(anonymous, field)
+			continue
+		}
+		if !isExportableField(field) || field.Tag.Get(tagKey) != exportTagValue {
+			continue
+		}
+		out[field.Name] = structValue.Field(i).Interface()
+	}
+
+	for _, field := range anonymous {
+		extractAnonymous(out, structValue, field, tagKey)
+	}
+}
+
+func extractAnonymous(out map[string]interface{}, structValue reflect.Value, field reflect.StructField, tagKey string) {
+	if !isExportableField(field) {
+		return
+	}
+
+	fieldValue := structValue.FieldByName(field.Name)
+	for fieldValue.Kind() == reflect.Ptr {
+		if fieldValue.IsNil() {
+			if field.Tag.Get(tagKey) == exportTagValue {
+				out[field.Name] = nil
+			}
+			return
+		}
+		fieldValue = fieldValue.Elem()
+	}
+
+	if fieldValue.Kind() == reflect.Interface {
+		return
+	}
+	if fieldValue.Kind() != reflect.Struct {
+		return
+	}
+
+	if field.Tag.Get(tagKey) == exportTagValue {
+		nested := make(map[string]interface{})
+		extractInto(nested, fieldValue, tagKey)
+		out[field.Name] = nested
+		return
+	}
+
+	embedded := make(map[string]interface{})
+	extractInto(embedded, fieldValue, tagKey)
+	for name, value := range embedded {
+		if _, collides := out[name]; collides {
+			out[fieldValue.Type().Name()+"."+name] = value
+			continue
+		}
+		out[name] = value
+	}
+}
@@ -0,0 +1,89 @@
+package reflections
+
+import (
+	"reflect"
+	"testing"
+)
+
+type ExtractEmbedded struct {
+	ID   string `reflections:"export"`
+	Name string
+}
+
+type extractNested struct {
+	Code string `reflections:"export"`
+}
+
+type extractRoot struct {
+	ExtractEmbedded
+	Nested extractNested `reflections:"export"`
+	ID     string        `reflections:"export"`
+	Hidden string
+}
+
+func TestExtractFlattensEmbeddedAndResolvesCollisions(t *testing.T) {
+	r := extractRoot{
+		ExtractEmbedded: ExtractEmbedded{ID: "embedded-id", Name: "skip-me"},
+		ID:              "parent-id",
+	}
+	r.Nested.Code = "abc"
+
+	got, err := Extract(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"ID":                 "parent-id",
+		"ExtractEmbedded.ID": "embedded-id",
+		"Nested":             r.Nested,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+type ExtractMarkedEmbed struct {
+	Code string `reflections:"export"`
+}
+
+type extractRootWithTaggedEmbed struct {
+	ExtractMarkedEmbed `reflections:"export"`
+}
+
+func TestExtractNestsEmbeddedWhenItselfTagged(t *testing.T) {
+	r := extractRootWithTaggedEmbed{ExtractMarkedEmbed{Code: "c1"}}
+
+	got, err := Extract(&r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nested, ok := got["ExtractMarkedEmbed"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested map under type name, got %#v", got)
+	}
+	if nested["Code"] != "c1" {
+		t.Fatalf("nested map missing Code: %#v", nested)
+	}
+}
+
+type extractInterfaceEmbed struct {
+	error
+	Name string `reflections:"export"`
+}
+
+func TestExtractSkipsEmbeddedInterfaces(t *testing.T) {
+	r := extractInterfaceEmbed{Name: "n1"}
+
+	got, err := ExtractWithTag(&r, "reflections")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["Name"] != "n1" {
+		t.Fatalf("got %#v", got)
+	}
+	if _, ok := got["error"]; ok {
+		t.Fatalf("embedded interface should be skipped: %#v", got)
+	}
+}
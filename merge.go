@@ -0,0 +1,137 @@
+package reflections
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrSkipTransform is returned by a MergeOption's Transformer to decline a
+// field, falling back to Merge's default copy behavior for it.
+var ErrSkipTransform = errors.New("reflections: transformer declined field")
+
+// MergeOption configures the behavior of Merge.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	overwriteZero bool
+	appendSlices  bool
+	transformer   func(dst, src reflect.Value) error
+}
+
+// OverwriteZero makes Merge copy a src field onto dst even when the src
+// field is the zero value. Without it, zero-valued src fields leave dst
+// untouched.
+func OverwriteZero() MergeOption {
+	return func(c *mergeConfig) { c.overwriteZero = true }
+}
+
+// AppendSlices makes Merge append src's slice elements to dst's instead of
+// replacing dst's slice outright.
+func AppendSlices() MergeOption {
+	return func(c *mergeConfig) { c.appendSlices = true }
+}
+
+// WithTransformer registers a hook consulted for every field before
+// Merge's default logic runs. Returning ErrSkipTransform falls back to the
+// default behavior for that field; any other error aborts the merge. This
+// is the escape hatch for types like time.Time, where "non-zero" and
+// "append" don't mean what they mean for plain structs and slices.
+func WithTransformer(fn func(dst, src reflect.Value) error) MergeOption {
+	return func(c *mergeConfig) { c.transformer = fn }
+}
+
+// Merge copies non-zero fields from src onto dst, recursing into nested
+// structs. dst must be a pointer to a struct; src may be a struct or a
+// pointer to one of the same type. Fields tagged `reflections:"-"` are
+// never touched.
+func Merge(dst, src interface{}, opts ...MergeOption) error {
+	if !isPointer(dst) {
+		return errors.New("Cannot use Merge on a non-pointer dst")
+	}
+
+	cfg := &mergeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return nil
+		}
+		srcValue = srcValue.Elem()
+	}
+
+	if dstValue.Kind() != reflect.Struct || srcValue.Kind() != reflect.Struct {
+		return errors.New("Merge requires dst and src to be structs or pointers to structs")
+	}
+	if dstValue.Type() != srcValue.Type() {
+		return fmt.Errorf("Merge requires dst and src of the same type (got %s and %s)", dstValue.Type(), srcValue.Type())
+	}
+
+	return mergeStruct(dstValue, srcValue, cfg)
+}
+
+func mergeStruct(dst, src reflect.Value, cfg *mergeConfig) error {
+	structType := dst.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !isExportableField(field) || isOptedOut(field) {
+			continue
+		}
+
+		dstField := dst.Field(i)
+		srcField := src.Field(i)
+
+		if cfg.transformer != nil {
+			err := cfg.transformer(dstField, srcField)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, ErrSkipTransform) {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+
+		if srcField.Kind() == reflect.Struct && hasExportedField(srcField.Type()) {
+			if err := mergeStruct(dstField, srcField, cfg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if srcField.IsZero() {
+			if cfg.overwriteZero {
+				dstField.Set(srcField)
+			}
+			continue
+		}
+
+		if cfg.appendSlices && srcField.Kind() == reflect.Slice {
+			dstField.Set(reflect.AppendSlice(dstField, srcField))
+			continue
+		}
+
+		dstField.Set(srcField)
+	}
+
+	return nil
+}
+
+// hasExportedField reports whether t (a struct type) has at least one
+// field mergeStruct can recurse into. Types like time.Time are exported
+// structs built entirely out of unexported fields; without this check
+// mergeStruct would recurse into them, find nothing to copy, and silently
+// leave dst's field untouched. Such types are instead merged as opaque
+// values via the same zero-check/overwrite logic as any scalar field.
+func hasExportedField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if isExportableField(t.Field(i)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package reflections
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mergeInner struct {
+	Code string
+}
+
+type mergeTarget struct {
+	Name    string
+	Count   int
+	Inner   mergeInner
+	Tags    []string
+	Ignored string `reflections:"-"`
+	When    time.Time
+}
+
+func TestMergeCopiesNonZeroFieldsAndRecursesNestedStructs(t *testing.T) {
+	dst := mergeTarget{Name: "old", Count: 1, Tags: []string{"a"}, Ignored: "keep"}
+	src := mergeTarget{Name: "new", Inner: mergeInner{Code: "c1"}, Tags: []string{"b"}, Ignored: "drop"}
+
+	if err := Merge(&dst, &src, AppendSlices()); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "new" {
+		t.Fatalf("expected Name overwritten, got %q", dst.Name)
+	}
+	if dst.Count != 1 {
+		t.Fatalf("zero-valued Count should not overwrite, got %d", dst.Count)
+	}
+	if dst.Inner.Code != "c1" {
+		t.Fatalf("nested struct not merged: %+v", dst.Inner)
+	}
+	if len(dst.Tags) != 2 || dst.Tags[0] != "a" || dst.Tags[1] != "b" {
+		t.Fatalf("slices not appended: %v", dst.Tags)
+	}
+	if dst.Ignored != "keep" {
+		t.Fatalf("opted-out field was touched: %q", dst.Ignored)
+	}
+}
+
+// Regression test: time.Time is an exported struct built entirely out of
+// unexported fields. mergeStruct must treat it as an opaque value instead
+// of recursing, finding nothing exported to copy, and silently leaving the
+// destination field untouched.
+func TestMergeCopiesOpaqueStructFields(t *testing.T) {
+	dst := mergeTarget{Name: "old"}
+	src := mergeTarget{Name: "new", When: time.Now()}
+
+	if err := Merge(&dst, &src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.When.IsZero() {
+		t.Fatal("time.Time field was not merged")
+	}
+}
+
+func TestMergeOverwriteZero(t *testing.T) {
+	dst := mergeTarget{Count: 5}
+	src := mergeTarget{Count: 0}
+
+	if err := Merge(&dst, &src, OverwriteZero()); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Count != 0 {
+		t.Fatalf("OverwriteZero should have zeroed Count, got %d", dst.Count)
+	}
+}
+
+func TestMergeTransformerCanDeclineAndHandle(t *testing.T) {
+	dst := mergeTarget{Name: "old", When: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	src := mergeTarget{Name: "new", When: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	timeType := reflect.TypeOf(time.Time{})
+	err := Merge(&dst, &src, WithTransformer(func(dstField, srcField reflect.Value) error {
+		if dstField.Type() != timeType {
+			return ErrSkipTransform
+		}
+		// Keep the later of the two times instead of Merge's default
+		// "non-zero src wins" rule.
+		d := dstField.Interface().(time.Time)
+		s := srcField.Interface().(time.Time)
+		if s.After(d) {
+			dstField.Set(srcField)
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "new" {
+		t.Fatalf("non-time field should still merge normally, got %q", dst.Name)
+	}
+	if !dst.When.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("transformer result not applied: %v", dst.When)
+	}
+}
@@ -0,0 +1,114 @@
+package reflections
+
+import "testing"
+
+type pathAddress struct {
+	Zip string
+}
+
+type pathUser struct {
+	Address pathAddress
+}
+
+type pathRoot struct {
+	Users []pathUser
+	Meta  map[string]*pathAddress
+	Next  *pathRoot
+}
+
+func TestGetFieldPath(t *testing.T) {
+	r := pathRoot{
+		Users: []pathUser{{Address: pathAddress{Zip: "11111"}}},
+		Meta:  map[string]*pathAddress{"region": {Zip: "22222"}},
+	}
+	r.Next = &pathRoot{Users: []pathUser{{Address: pathAddress{Zip: "33333"}}}}
+
+	cases := []struct {
+		name string
+		path string
+		want interface{}
+	}{
+		{"slice index then struct", "Users[0].Address.Zip", "11111"},
+		{"quoted map key", `Meta["region"].Zip`, "22222"},
+		{"bare map key", "Meta[region].Zip", "22222"},
+		{"pointer chain", "Next.Users[0].Address.Zip", "33333"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GetField(&r, tc.path)
+			if err != nil {
+				t.Fatalf("GetField(%q): %v", tc.path, err)
+			}
+			if got != tc.want {
+				t.Fatalf("GetField(%q) = %v, want %v", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetFieldPathErrors(t *testing.T) {
+	r := pathRoot{Users: []pathUser{{}}}
+
+	cases := []string{
+		"Users[5].Address.Zip", // out of range
+		"Meta[region].Zip",     // nil map
+		"Users[0].Missing",     // no such field
+	}
+
+	for _, path := range cases {
+		if _, err := GetField(&r, path); err == nil {
+			t.Fatalf("GetField(%q): expected error, got none", path)
+		}
+	}
+}
+
+func TestSetFieldPath(t *testing.T) {
+	r := pathRoot{Users: []pathUser{{}}}
+
+	if err := SetField(&r, "Users[0].Address.Zip", "99999"); err != nil {
+		t.Fatalf("SetField slice/struct path: %v", err)
+	}
+	if r.Users[0].Address.Zip != "99999" {
+		t.Fatalf("got %q", r.Users[0].Address.Zip)
+	}
+
+	if err := SetField(&r, `Meta["new"]`, &pathAddress{Zip: "x"}); err != nil {
+		t.Fatalf("SetField allocating nil map: %v", err)
+	}
+	if r.Meta == nil || r.Meta["new"].Zip != "x" {
+		t.Fatalf("map not allocated/set: %+v", r.Meta)
+	}
+
+	if err := SetField(&r, "Users[5].Address.Zip", "nope"); err == nil {
+		t.Fatal("expected out-of-range error, got none")
+	}
+}
+
+func TestHasFieldPath(t *testing.T) {
+	r := pathRoot{Users: []pathUser{{}}}
+
+	ok, err := HasField(&r, "Users[0].Address.Zip")
+	if err != nil || !ok {
+		t.Fatalf("HasField = %v, %v; want true, nil", ok, err)
+	}
+
+	ok, err = HasField(&r, "Users[0].Missing")
+	if err != nil || ok {
+		t.Fatalf("HasField = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestGetFieldTagPath(t *testing.T) {
+	type tagged struct {
+		Nested struct {
+			Value string `json:"value"`
+		}
+	}
+	var v tagged
+
+	tag, err := GetFieldTag(&v, "Nested.Value", "json")
+	if err != nil || tag != "value" {
+		t.Fatalf("GetFieldTag = %q, %v; want \"value\", nil", tag, err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -43,9 +44,10 @@ func GetFieldKind(obj interface{}, name string) (reflect.Kind, error) {
 }
 
 // GetFieldTag returns the provided obj field tag value. obj can whether
-// be a structure or pointer to structure.
-func GetFieldTag(obj interface{}, fieldName, tagKey string) (string, error) {
-	field, err := getInnerFieldType(obj, fieldName, fieldName)
+// be a structure or pointer to structure. name accepts the same
+// slice/map/pointer path syntax as GetField.
+func GetFieldTag(obj interface{}, name, tagKey string) (string, error) {
+	field, err := getInnerFieldType(obj, name)
 	if err != nil {
 		return "", err
 	}
@@ -60,117 +62,156 @@ func GetFieldTag(obj interface{}, fieldName, tagKey string) (string, error) {
 // SetField sets the provided obj field with provided value. obj param has
 // to be a pointer to a struct, otherwise it will soundly fail. Provided
 // value type should match with the struct field you're trying to set.
+//
+// name follows the same dotted path syntax as GetField, e.g.
+// "Users[0].Address.Zip" or `Meta["region"].Code`. A nil map encountered
+// on the final segment is allocated before the value is stored.
 func SetField(obj interface{}, name string, value interface{}) error {
-	// Fetch the field reflect.Value
-	//	structValue := reflect.ValueOf(obj).Elem()
-	structFieldValue, err := getStructField(obj, name)
+	if !isPointer(obj) {
+		return errors.New("Cannot use SetField on a non-pointer struct")
+	}
+
+	segments, err := parsePath(name)
 	if err != nil {
 		return err
 	}
-
-	if !structFieldValue.IsValid() {
-		return fmt.Errorf("No such field: %s in obj", name)
+	if len(segments) == 0 {
+		return fmt.Errorf("Empty field path")
 	}
 
-	// If obj field value is not settable an error is thrown
-	if !structFieldValue.CanSet() {
-		return fmt.Errorf("Cannot set %s field value", name)
+	container := reflectValue(obj)
+	for _, seg := range segments[:len(segments)-1] {
+		container, _, err = stepInto(container, seg)
+		if err != nil {
+			return err
+		}
 	}
 
-	structFieldType := structFieldValue.Type()
-	val := reflect.ValueOf(value)
-	if structFieldType != val.Type() {
-		invalidTypeError := fmt.Errorf("Provided value type (%v) didn't match obj field type(%v)\n", val.Type(), structFieldType)
-		return invalidTypeError
+	return setSegment(container, segments[len(segments)-1], value)
+}
+
+// setSegment applies the final path segment of a SetField call against
+// container, allocating a nil map when the segment is a map key.
+func setSegment(container reflect.Value, seg pathSegment, value interface{}) error {
+	container = indirect(container)
+	if !container.IsValid() {
+		return fmt.Errorf("Cannot set %s: nil pointer in path", seg.String())
 	}
+	val := reflect.ValueOf(value)
 
-	structFieldValue.Set(val)
-	return nil
+	switch seg.kind {
+	case segIndex:
+		if container.Kind() != reflect.Slice && container.Kind() != reflect.Array {
+			return fmt.Errorf("Cannot index into non-slice/array kind %s", container.Kind())
+		}
+		if seg.index < 0 || seg.index >= container.Len() {
+			return fmt.Errorf("Index %d out of range (len %d)", seg.index, container.Len())
+		}
+		elem := container.Index(seg.index)
+		if !elem.CanSet() {
+			return fmt.Errorf("Cannot set index %d", seg.index)
+		}
+		if elem.Type() != val.Type() {
+			return fmt.Errorf("Provided value type (%v) didn't match obj field type(%v)\n", val.Type(), elem.Type())
+		}
+		elem.Set(val)
+		return nil
+
+	case segKey:
+		if container.Kind() != reflect.Map {
+			return fmt.Errorf("Cannot key into non-map kind %s", container.Kind())
+		}
+		if container.IsNil() {
+			if !container.CanSet() {
+				return fmt.Errorf("Cannot allocate nil map for key %s", seg.key)
+			}
+			container.Set(reflect.MakeMap(container.Type()))
+		}
+		keyValue, err := convertMapKey(container.Type().Key(), seg.key)
+		if err != nil {
+			return err
+		}
+		if container.Type().Elem() != val.Type() {
+			return fmt.Errorf("Provided value type (%v) didn't match obj field type(%v)\n", val.Type(), container.Type().Elem())
+		}
+		container.SetMapIndex(keyValue, val)
+		return nil
+
+	default:
+		if container.Kind() != reflect.Struct {
+			return fmt.Errorf("Cannot access field %s on non-struct kind %s", seg.field, container.Kind())
+		}
+		fieldValue := container.FieldByName(seg.field)
+		if !fieldValue.IsValid() {
+			return fmt.Errorf("No such field: %s in obj", seg.field)
+		}
+		if !fieldValue.CanSet() {
+			return fmt.Errorf("Cannot set %s field value", seg.field)
+		}
+		if fieldValue.Type() != val.Type() {
+			return fmt.Errorf("Provided value type (%v) didn't match obj field type(%v)\n", val.Type(), fieldValue.Type())
+		}
+		fieldValue.Set(val)
+		return nil
+	}
 }
 
-// HasField checks if the provided field name is part of a struct. obj can whether
-// be a structure or pointer to structure.
+// HasField checks if the provided field name is part of a struct. obj can
+// whether be a structure or pointer to structure. name accepts the same
+// path syntax as GetField.
 func HasField(obj interface{}, name string) (bool, error) {
 	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
 		return false, errors.New("Cannot use GetField on a non-struct interface")
 	}
 
-	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	field, ok := objType.FieldByName(name)
-	if !ok || !isExportableField(field) {
+	segments, err := parsePath(name)
+	if err != nil {
+		return false, err
+	}
+
+	_, sf, err := walkPath(reflectValue(obj), segments)
+	if err != nil {
+		return false, nil
+	}
+	if sf != nil && !isExportableField(*sf) {
 		return false, nil
 	}
 
 	return true, nil
 }
 
-// FieldsNames returns the struct fields names list. obj can whether
-// be a structure or pointer to structure.
+// FieldsNames returns the struct fields names list, including nested
+// struct and pointer-to-struct fields flattened under a dotted path (e.g.
+// "Address.Zip"). obj can whether be a structure or pointer to structure.
 func FieldsNames(obj interface{}) ([]string, error) {
-	return fieldsNames(obj, "")
-}
-
-func fieldsNames(obj interface{}, parent string) ([]string, error) {
 	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
 		return nil, errors.New("Cannot use GetField on a non-struct interface")
 	}
 
-	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	fieldsCount := objType.NumField()
-
-	var fields []string
-	for i := 0; i < fieldsCount; i++ {
-		field := objType.Field(i)
-		var fieldName string
-		if isExportableField(field) {
-			fieldName = field.Name
-			if len(parent) > 0 {
-				fieldName = parent + "." + fieldName
-			}
-			fields = append(fields, fieldName)
-		}
-		if k := objValue.Field(i).Kind(); k == reflect.Struct || k == reflect.Ptr {
-			nestedFields, err := fieldsNames(objValue.Field(i).Interface(), fieldName)
-			if err == nil {
-				fields = append(fields, nestedFields...)
-			} else {
-				return fields, err
-			}
-		}
-	}
+	meta := cachedTypeMeta(reflectValue(obj).Type())
 
-	return fields, nil
+	names := make([]string, len(meta.recursive))
+	for i, f := range meta.recursive {
+		names[i] = f.DottedName
+	}
+	return names, nil
 }
 
-// Fields returns the struct fields list. obj can whether
-// be a structure or pointer to structure.
+// Fields returns the struct fields list, including nested struct and
+// pointer-to-struct fields flattened in along with their parents. obj can
+// whether be a structure or pointer to structure.
 func Fields(obj interface{}) ([]reflect.StructField, error) {
 	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
 		return nil, errors.New("Cannot use GetField on a non-struct interface")
 	}
 
-	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	fieldsCount := objType.NumField()
-
-	var fields []reflect.StructField
-	for i := 0; i < fieldsCount; i++ {
-		field := objType.Field(i)
-		if isExportableField(field) {
-			fields = append(fields, field)
-		}
-		if k := objValue.Field(i).Kind(); k == reflect.Struct || k == reflect.Ptr {
-			nestedFields, err := Fields(objValue.Field(i).Interface())
-			if err == nil {
-				fields = append(fields, nestedFields...)
-			} else {
-				return fields, err
-			}
-		}
-	}
+	structType := reflectValue(obj).Type()
+	meta := cachedTypeMeta(structType)
 
+	fields := make([]reflect.StructField, len(meta.recursive))
+	for i, f := range meta.recursive {
+		fields[i] = structType.FieldByIndex(f.Index)
+	}
 	return fields, nil
 }
 
@@ -182,19 +223,14 @@ func Items(obj interface{}) (map[string]interface{}, error) {
 	}
 
 	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	fieldsCount := objType.NumField()
+	meta := cachedTypeMeta(objValue.Type())
 
 	items := make(map[string]interface{})
-
-	for i := 0; i < fieldsCount; i++ {
-		field := objType.Field(i)
-		fieldValue := objValue.Field(i)
-
+	for _, f := range meta.direct {
 		// Make sure only exportable and addressable fields are
 		// returned by Items
-		if isExportableField(field) {
-			items[field.Name] = fieldValue.Interface()
+		if f.Exported {
+			items[f.Name] = objValue.FieldByIndex(f.Index).Interface()
 		}
 	}
 
@@ -208,17 +244,12 @@ func Tags(obj interface{}, key string) (map[string]string, error) {
 		return nil, errors.New("Cannot use GetField on a non-struct interface")
 	}
 
-	objValue := reflectValue(obj)
-	objType := objValue.Type()
-	fieldsCount := objType.NumField()
+	meta := cachedTypeMeta(reflectValue(obj).Type())
 
 	tags := make(map[string]string)
-
-	for i := 0; i < fieldsCount; i++ {
-		structField := objType.Field(i)
-
-		if isExportableField(structField) {
-			tags[structField.Name] = structField.Tag.Get(key)
+	for _, f := range meta.direct {
+		if f.Exported {
+			tags[f.Name] = f.Tag.Get(key)
 		}
 	}
 
@@ -252,10 +283,6 @@ func hasValidType(obj interface{}, types []reflect.Kind) bool {
 	return false
 }
 
-func isStruct(obj interface{}) bool {
-	return reflect.TypeOf(obj).Kind() == reflect.Struct
-}
-
 func isPointer(obj interface{}) bool {
 	return reflect.TypeOf(obj).Kind() == reflect.Ptr
 }
@@ -291,106 +318,212 @@ func setDefaultValue(v reflect.Value, name string, value interface{}) error {
 	return nil
 }
 
-func getStructField(obj interface{}, name string) (reflect.Value, error) {
-	fmt.Printf("working on %v\n", name)
-	if i := strings.Index(name, "."); i > -1 {
-		rv := reflect.Value{}
-		currFieldName := name[0:i]
-		objValue := reflectValue(obj)
-		field := objValue.FieldByName(currFieldName)
-		if !field.IsValid() {
-			return rv, fmt.Errorf("No such field %s in obj", currFieldName)
-		}
-		if !isStruct(field) {
-			return rv, fmt.Errorf("Field %s expected to be an struct", currFieldName)
-		}
-		nextFieldName := name[i+1 : len(name)]
-		return getStructField(getStructFieldValue(field), nextFieldName)
+func getInnerField(obj interface{}, name string) (reflect.Value, error) {
+	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return reflect.Value{}, errors.New("Cannot use GetField on a non-struct interface")
+	}
+
+	segments, err := parsePath(name)
+	if err != nil {
+		return reflect.Value{}, err
 	}
-	return getNestedStructField(obj, name)
+
+	value, _, err := walkPath(reflectValue(obj), segments)
+	return value, err
 }
 
-func getStructFieldValue(field reflect.Value) interface{} {
-	if reflect.TypeOf(field.Interface()).Kind() == reflect.Ptr {
-		return field.Interface()
+func getInnerFieldType(obj interface{}, name string) (reflect.StructField, error) {
+	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
+		return reflect.StructField{}, errors.New("Cannot use GetField on a non-struct interface")
 	}
-	if field.CanAddr() {
-		return field.Addr()
+
+	segments, err := parsePath(name)
+	if err != nil {
+		return reflect.StructField{}, err
 	}
-	return field
-}
 
-func getNestedStructField(obj interface{}, name string) (reflect.Value, error) {
-	if reflect.TypeOf(obj).Kind() == reflect.Ptr {
-		structValue := reflect.ValueOf(obj).Elem()
-		return structValue.FieldByName(name), nil
+	_, sf, err := walkPath(reflectValue(obj), segments)
+	if err != nil {
+		return reflect.StructField{}, err
 	}
-	var structValue reflect.Value
-	switch obj.(type) {
-	case reflect.Value:
-		structValue = reflect.Indirect(obj.(reflect.Value))
+	if sf == nil {
+		return reflect.StructField{}, fmt.Errorf("%s does not resolve to a struct field", name)
+	}
+	return *sf, nil
+}
+
+// pathSegment is one step of a dotted field path: a struct field name, a
+// slice/array index (Users[0]), or a map key (Meta["region"] or Meta[region]).
+type pathSegment struct {
+	kind  segmentKind
+	field string
+	index int
+	key   string
+}
+
+type segmentKind int
+
+const (
+	segField segmentKind = iota
+	segIndex
+	segKey
+)
+
+func (s pathSegment) String() string {
+	switch s.kind {
+	case segIndex:
+		return fmt.Sprintf("[%d]", s.index)
+	case segKey:
+		return fmt.Sprintf("[%q]", s.key)
 	default:
-		structValue = reflect.Indirect(reflectValue(obj))
+		return s.field
 	}
-	f := structValue.FieldByName(name)
-	if f.CanAddr() {
-		return reflect.Indirect(f.Addr()), nil
+}
+
+// parsePath splits a text/template-style field path such as
+// `Users[0].Address.Zip` or `Meta["region"].Code` into its segments.
+func parsePath(name string) ([]pathSegment, error) {
+	var segments []pathSegment
+	i, n := 0, len(name)
+
+	for i < n {
+		switch {
+		case name[i] == '.':
+			i++
+		case name[i] == '[':
+			seg, end, err := parseBracket(name, i)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+			i = end
+		default:
+			start := i
+			for i < n && name[i] != '.' && name[i] != '[' {
+				i++
+			}
+			segments = append(segments, pathSegment{kind: segField, field: name[start:i]})
+		}
 	}
-	return reflect.Indirect(f.Elem()), nil
+
+	return segments, nil
 }
 
-func getInnerField(obj interface{}, name string) (reflect.Value, error) {
-	field := reflect.Value{}
-	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
-		return field, errors.New("Cannot use GetField on a non-struct interface")
+// parseBracket parses a single `[...]` segment starting at name[start] and
+// returns the segment along with the index just past the closing bracket.
+func parseBracket(name string, start int) (pathSegment, int, error) {
+	end := strings.IndexByte(name[start:], ']')
+	if end == -1 {
+		return pathSegment{}, 0, fmt.Errorf("unterminated '[' in path %q", name)
 	}
+	end += start
+	inner := name[start+1 : end]
 
-	objValue := reflectValue(obj)
-	if i := strings.Index(name, "."); i > -1 {
-		currFieldName := name[0:i]
-		field = objValue.FieldByName(currFieldName)
-		if !field.IsValid() {
-			return field, fmt.Errorf("No such field: %s in1 obj", name)
-		}
-		if !isStruct(field) {
-			return field, fmt.Errorf("Field %s expected to be an struct", currFieldName)
-		}
-		nextFieldName := name[i+1 : len(name)]
-		return getInnerField(field.Interface(), nextFieldName)
+	if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+		return pathSegment{kind: segKey, key: inner[1 : len(inner)-1]}, end + 1, nil
 	}
-	field = objValue.FieldByName(name)
-	if !field.IsValid() {
-		return field, fmt.Errorf("No such field: %s in obj", name)
+	if idx, err := strconv.Atoi(inner); err == nil {
+		return pathSegment{kind: segIndex, index: idx}, end + 1, nil
 	}
-	return field, nil
+	return pathSegment{kind: segKey, key: inner}, end + 1, nil
 }
 
-func getInnerFieldType(obj interface{}, fullName, name string) (reflect.StructField, error) {
-	field := reflect.StructField{}
-	if !hasValidType(obj, []reflect.Kind{reflect.Struct, reflect.Ptr}) {
-		return field, errors.New("Cannot use GetField on a non-struct interface")
+// walkPath resolves segments against root, mirroring the way text/template
+// evaluates a field path: structs dispatch through the cached promoted-field
+// index path (the same field FieldByName would find), slices and arrays
+// through Index, maps through MapIndex, and pointers/interfaces are
+// dereferenced transparently along the way. The returned *reflect.StructField
+// is non-nil only when the last segment resolved to a struct field.
+func walkPath(root reflect.Value, segments []pathSegment) (reflect.Value, *reflect.StructField, error) {
+	cur := root
+	var sf *reflect.StructField
+
+	for _, seg := range segments {
+		var err error
+		cur, sf, err = stepInto(cur, seg)
+		if err != nil {
+			return reflect.Value{}, nil, err
+		}
 	}
 
-	objValue := reflectValue(obj)
-	if i := strings.Index(name, "."); i > -1 {
-		currFieldName := name[0:i]
-		fieldRv := objValue.FieldByName(currFieldName)
-		if !fieldRv.IsValid() {
-			return field, fmt.Errorf("No such field: %s in obj", name)
+	return cur, sf, nil
+}
+
+func stepInto(v reflect.Value, seg pathSegment) (reflect.Value, *reflect.StructField, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return reflect.Value{}, nil, fmt.Errorf("nil pointer while resolving %s", seg.String())
+	}
+
+	switch seg.kind {
+	case segIndex:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return reflect.Value{}, nil, fmt.Errorf("cannot index into non-slice/array kind %s", v.Kind())
 		}
-		if !isStruct(fieldRv) {
-			return field, fmt.Errorf("Field %s expected to be an struct", currFieldName)
+		if seg.index < 0 || seg.index >= v.Len() {
+			return reflect.Value{}, nil, fmt.Errorf("index %d out of range (len %d)", seg.index, v.Len())
+		}
+		return v.Index(seg.index), nil, nil
+
+	case segKey:
+		if v.Kind() != reflect.Map {
+			return reflect.Value{}, nil, fmt.Errorf("cannot key into non-map kind %s", v.Kind())
+		}
+		keyValue, err := convertMapKey(v.Type().Key(), seg.key)
+		if err != nil {
+			return reflect.Value{}, nil, err
 		}
-		nextFieldName := name[i+1 : len(name)]
-		return getInnerFieldType(fieldRv.Interface(), fullName, nextFieldName)
+		mv := v.MapIndex(keyValue)
+		if !mv.IsValid() {
+			return reflect.Value{}, nil, fmt.Errorf("no such key: %s in map", seg.key)
+		}
+		return mv, nil, nil
+
+	default:
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, nil, fmt.Errorf("cannot access field %s on non-struct kind %s", seg.field, v.Kind())
+		}
+		meta := cachedTypeMeta(v.Type())
+		index, ok := meta.promoted[seg.field]
+		if !ok {
+			return reflect.Value{}, nil, fmt.Errorf("No such field: %s in obj", seg.field)
+		}
+		structField := v.Type().FieldByIndex(index)
+		return v.FieldByIndex(index), &structField, nil
 	}
-	if !objValue.IsValid() {
-		return field, fmt.Errorf("Nil pointer: %s in obj", fullName)
+}
+
+// indirect dereferences pointers and interfaces, returning an invalid Value
+// if it bottoms out on a nil one.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
 	}
-	objType := objValue.Type()
-	field, ok := objType.FieldByName(name)
-	if !ok {
-		return field, fmt.Errorf("No such field: %s in obj", name)
+	return v
+}
+
+// convertMapKey converts the raw bracketed text of a map segment (e.g. the
+// `region` in Meta["region"]) into a reflect.Value assignable to keyType.
+func convertMapKey(keyType reflect.Type, raw string) (reflect.Value, error) {
+	switch keyType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(raw).Convert(keyType), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot use %q as map key of kind %s", raw, keyType.Kind())
+		}
+		return reflect.ValueOf(n).Convert(keyType), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot use %q as map key of kind %s", raw, keyType.Kind())
+		}
+		return reflect.ValueOf(n).Convert(keyType), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported map key kind: %s", keyType.Kind())
 	}
-	return field, nil
 }
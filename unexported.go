@@ -0,0 +1,59 @@
+package reflections
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// GetUnexportedField returns the value of obj's unexported field name. obj
+// must be a pointer to a struct so the field is addressable; GetField
+// cannot return unexported fields (reflect panics on them), but test
+// scaffolding and interop
+// with third-party types sometimes need to read through them anyway.
+func GetUnexportedField(obj interface{}, name string) (interface{}, error) {
+	field, err := unexportedField(obj, name)
+	if err != nil {
+		return nil, err
+	}
+	return field.Interface(), nil
+}
+
+// SetUnexportedField sets obj's unexported field name to value. obj must
+// be a pointer to a struct.
+func SetUnexportedField(obj interface{}, name string, value interface{}) error {
+	field, err := unexportedField(obj, name)
+	if err != nil {
+		return err
+	}
+
+	val := reflect.ValueOf(value)
+	if field.Type() != val.Type() {
+		return fmt.Errorf("Provided value type (%v) didn't match obj field type(%v)\n", val.Type(), field.Type())
+	}
+
+	field.Set(val)
+	return nil
+}
+
+// unexportedField resolves name to an addressable, writable alias of obj's
+// unexported field by reflect.NewAt-ing over its address, bypassing the
+// isExportableField gate that the rest of the package enforces.
+func unexportedField(obj interface{}, name string) (reflect.Value, error) {
+	if !isPointer(obj) {
+		return reflect.Value{}, errors.New("Cannot use GetUnexportedField/SetUnexportedField on a non-pointer struct")
+	}
+
+	structValue := reflect.ValueOf(obj).Elem()
+	if structValue.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("Cannot use GetUnexportedField/SetUnexportedField on a non-struct interface")
+	}
+
+	field := structValue.FieldByName(name)
+	if !field.IsValid() {
+		return reflect.Value{}, fmt.Errorf("No such field: %s in obj", name)
+	}
+
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), nil
+}
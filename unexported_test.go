@@ -0,0 +1,55 @@
+package reflections
+
+import "testing"
+
+type unexportedHolder struct {
+	secret string
+	Public string
+}
+
+func TestGetUnexportedField(t *testing.T) {
+	h := unexportedHolder{secret: "shh", Public: "hi"}
+
+	got, err := GetUnexportedField(&h, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "shh" {
+		t.Fatalf("got %v, want %q", got, "shh")
+	}
+}
+
+func TestSetUnexportedField(t *testing.T) {
+	h := unexportedHolder{secret: "shh"}
+
+	if err := SetUnexportedField(&h, "secret", "updated"); err != nil {
+		t.Fatal(err)
+	}
+	if h.secret != "updated" {
+		t.Fatalf("got %q, want %q", h.secret, "updated")
+	}
+}
+
+func TestSetUnexportedFieldTypeMismatch(t *testing.T) {
+	h := unexportedHolder{secret: "shh"}
+
+	if err := SetUnexportedField(&h, "secret", 42); err == nil {
+		t.Fatal("expected type mismatch error, got none")
+	}
+}
+
+func TestUnexportedFieldRequiresPointer(t *testing.T) {
+	h := unexportedHolder{secret: "shh"}
+
+	if _, err := GetUnexportedField(h, "secret"); err == nil {
+		t.Fatal("expected error for non-pointer obj, got none")
+	}
+}
+
+func TestUnexportedFieldNoSuchField(t *testing.T) {
+	h := unexportedHolder{secret: "shh"}
+
+	if _, err := GetUnexportedField(&h, "Missing"); err == nil {
+		t.Fatal("expected error for missing field, got none")
+	}
+}